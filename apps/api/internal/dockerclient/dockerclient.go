@@ -0,0 +1,27 @@
+// Package dockerclient provides a single, process-wide Docker client so
+// handlers don't re-dial the daemon on every request.
+package dockerclient
+
+import (
+	"sync"
+
+	"github.com/docker/docker/client"
+)
+
+// apiVersion pins the client to a known-good Docker Engine API version.
+const apiVersion = "1.48"
+
+var (
+	once    sync.Once
+	cli     *client.Client
+	initErr error
+)
+
+// Get returns the shared Docker client, creating it on first use. The
+// returned client is safe for concurrent use by multiple handlers.
+func Get() (*client.Client, error) {
+	once.Do(func() {
+		cli, initErr = client.NewClientWithOpts(client.FromEnv, client.WithVersion(apiVersion))
+	})
+	return cli, initErr
+}
@@ -0,0 +1,40 @@
+// Package errdefs defines a small taxonomy of error categories that
+// handlers can return without knowing how those categories map to HTTP
+// status codes. Any error can opt into a category by implementing the
+// matching one-method interface, following the same pattern as moby's
+// api/errdefs package.
+package errdefs
+
+// ErrNotFound signals that the requested object does not exist.
+type ErrNotFound interface {
+	NotFound() bool
+}
+
+// ErrInvalidParameter signals that the caller supplied a malformed or
+// otherwise invalid request.
+type ErrInvalidParameter interface {
+	InvalidParameter() bool
+}
+
+// ErrConflict signals that the request conflicts with the current state
+// of the object it targets.
+type ErrConflict interface {
+	Conflict() bool
+}
+
+// ErrUnauthorized signals that the caller is not authorized to perform
+// the requested operation.
+type ErrUnauthorized interface {
+	Unauthorized() bool
+}
+
+// ErrNotModified signals that the operation was a no-op because the
+// target was already in the requested state.
+type ErrNotModified interface {
+	NotModified() bool
+}
+
+// ErrSystem signals an unexpected internal or system-level failure.
+type ErrSystem interface {
+	System() bool
+}
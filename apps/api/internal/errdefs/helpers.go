@@ -0,0 +1,36 @@
+package errdefs
+
+// errWithCategory wraps an error and tags it with a single category,
+// letting the original error still be recovered via errors.Unwrap.
+type errWithCategory struct {
+	error
+	category string
+}
+
+func (e *errWithCategory) Unwrap() error { return e.error }
+
+func (e *errWithCategory) NotFound() bool         { return e.category == "not-found" }
+func (e *errWithCategory) InvalidParameter() bool { return e.category == "invalid-parameter" }
+func (e *errWithCategory) Conflict() bool         { return e.category == "conflict" }
+func (e *errWithCategory) Unauthorized() bool     { return e.category == "unauthorized" }
+func (e *errWithCategory) NotModified() bool      { return e.category == "not-modified" }
+func (e *errWithCategory) System() bool           { return e.category == "system" }
+
+// NotFound wraps err so that errors.As(err, &ErrNotFound) succeeds.
+func NotFound(err error) error { return &errWithCategory{err, "not-found"} }
+
+// InvalidParameter wraps err so that errors.As(err, &ErrInvalidParameter)
+// succeeds.
+func InvalidParameter(err error) error { return &errWithCategory{err, "invalid-parameter"} }
+
+// Conflict wraps err so that errors.As(err, &ErrConflict) succeeds.
+func Conflict(err error) error { return &errWithCategory{err, "conflict"} }
+
+// Unauthorized wraps err so that errors.As(err, &ErrUnauthorized) succeeds.
+func Unauthorized(err error) error { return &errWithCategory{err, "unauthorized"} }
+
+// NotModified wraps err so that errors.As(err, &ErrNotModified) succeeds.
+func NotModified(err error) error { return &errWithCategory{err, "not-modified"} }
+
+// System wraps err so that errors.As(err, &ErrSystem) succeeds.
+func System(err error) error { return &errWithCategory{err, "system"} }
@@ -0,0 +1,66 @@
+package errdefs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCategoryWrappersSetExactlyOneCategory(t *testing.T) {
+	base := errors.New("boom")
+
+	cases := []struct {
+		name string
+		err  error
+		is   func(error) bool
+	}{
+		{"NotFound", NotFound(base), func(err error) bool {
+			var e ErrNotFound
+			return errors.As(err, &e) && e.NotFound()
+		}},
+		{"InvalidParameter", InvalidParameter(base), func(err error) bool {
+			var e ErrInvalidParameter
+			return errors.As(err, &e) && e.InvalidParameter()
+		}},
+		{"Conflict", Conflict(base), func(err error) bool {
+			var e ErrConflict
+			return errors.As(err, &e) && e.Conflict()
+		}},
+		{"Unauthorized", Unauthorized(base), func(err error) bool {
+			var e ErrUnauthorized
+			return errors.As(err, &e) && e.Unauthorized()
+		}},
+		{"NotModified", NotModified(base), func(err error) bool {
+			var e ErrNotModified
+			return errors.As(err, &e) && e.NotModified()
+		}},
+		{"System", System(base), func(err error) bool {
+			var e ErrSystem
+			return errors.As(err, &e) && e.System()
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if !tc.is(tc.err) {
+				t.Fatalf("%s(err) does not satisfy its own category interface", tc.name)
+			}
+			if !errors.Is(tc.err, base) {
+				t.Fatalf("%s(err) lost errors.Is relationship with the wrapped error", tc.name)
+			}
+		})
+	}
+}
+
+func TestCategoryWrappersDoNotCrossMatch(t *testing.T) {
+	err := NotFound(errors.New("missing"))
+
+	var invalidParam ErrInvalidParameter
+	if errors.As(err, &invalidParam) && invalidParam.InvalidParameter() {
+		t.Fatalf("NotFound error unexpectedly satisfies InvalidParameter")
+	}
+
+	var system ErrSystem
+	if errors.As(err, &system) && system.System() {
+		t.Fatalf("NotFound error unexpectedly satisfies System")
+	}
+}
@@ -0,0 +1,114 @@
+// Package eventbus fans a single Docker daemon event subscription out
+// to many listeners, so N HTTP clients subscribing to GET /events don't
+// each open their own connection to the daemon.
+package eventbus
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/client"
+)
+
+// subscriberQueue bounds how far a subscriber can lag before it's
+// dropped instead of blocking delivery to everyone else.
+const subscriberQueue = 64
+
+// reconnectDelay throttles how fast run resubscribes to the daemon
+// after its event stream ends, so a daemon that's down doesn't spin
+// the loop.
+const reconnectDelay = 1 * time.Second
+
+// Bus holds the single, daemon-wide event subscription and republishes
+// every message to each active subscriber.
+type Bus struct {
+	cli *client.Client
+
+	mu   sync.Mutex
+	subs map[chan events.Message]struct{}
+
+	startOnce sync.Once
+}
+
+// New returns a Bus backed by cli. The daemon subscription doesn't
+// start until the first call to Subscribe.
+func New(cli *client.Client) *Bus {
+	return &Bus{cli: cli, subs: make(map[chan events.Message]struct{})}
+}
+
+// Subscribe registers a new listener and returns its event channel
+// along with a cancel function the caller must invoke to unregister.
+func (b *Bus) Subscribe() (<-chan events.Message, func()) {
+	b.startOnce.Do(func() { go b.run() })
+
+	ch := make(chan events.Message, subscriberQueue)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	cancel := func() {
+		b.mu.Lock()
+		if _, ok := b.subs[ch]; ok {
+			delete(b.subs, ch)
+			close(ch)
+		}
+		b.mu.Unlock()
+	}
+	return ch, cancel
+}
+
+// run holds the daemon connection for the process lifetime, fanning
+// every event out to current subscribers. cli.Events ends its stream on
+// any error, reporting it on the error channel, so run resubscribes rather than
+// returning — otherwise a single daemon hiccup would permanently kill
+// /events for the rest of the process's life.
+func (b *Bus) run() {
+	ctx := context.Background()
+	for {
+		if !b.runOnce(ctx) {
+			time.Sleep(reconnectDelay)
+		}
+	}
+}
+
+// runOnce holds one daemon event subscription until it ends, reporting
+// whether it produced events before ending (used to avoid hot-looping
+// reconnects when every attempt fails immediately).
+func (b *Bus) runOnce(ctx context.Context) bool {
+	msgs, errs := b.cli.Events(ctx, events.ListOptions{})
+	received := false
+	for {
+		select {
+		case msg, ok := <-msgs:
+			if !ok {
+				return received
+			}
+			b.publish(msg)
+			received = true
+		case err := <-errs:
+			if err != nil {
+				log.Printf("[ERROR] Docker events stream error: %v", err)
+			}
+			return received
+		}
+	}
+}
+
+// publish delivers msg to every subscriber, dropping any subscriber
+// whose queue is full rather than blocking the rest.
+func (b *Bus) publish(msg events.Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- msg:
+		default:
+			log.Printf("[WARN] Dropping slow events subscriber")
+			delete(b.subs, ch)
+			close(ch)
+		}
+	}
+}
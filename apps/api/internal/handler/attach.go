@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/gorilla/mux"
+	"github.com/mxy680/meco/apps/api/internal/dockerclient"
+	"github.com/mxy680/meco/apps/api/internal/errdefs"
+)
+
+// Attach handles POST /containers/{id}/attach, hijacking the HTTP
+// connection (as moby's server does) and pumping raw bytes in both
+// directions between the client and the container's stdio.
+func Attach(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		writeError(w, errdefs.System(fmt.Errorf("connection does not support hijacking")))
+		return
+	}
+
+	cli, err := dockerclient.Get()
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("docker client error: %w", err)))
+		return
+	}
+
+	attach, err := cli.ContainerAttach(r.Context(), id, container.AttachOptions{
+		Stream: true,
+		Stdin:  true,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		writeError(w, notFoundOrSystem(id, err))
+		return
+	}
+	defer attach.Close()
+
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("failed to hijack connection: %w", err)))
+		return
+	}
+	defer conn.Close()
+
+	fmt.Fprint(conn, "HTTP/1.1 200 OK\r\nContent-Type: application/vnd.docker.raw-stream\r\n\r\n")
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(attach.Conn, buf)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(conn, attach.Reader)
+		done <- struct{}{}
+	}()
+	<-done
+
+	log.Printf("[INFO] Attach session for container %s closed", id)
+}
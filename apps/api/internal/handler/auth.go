@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	registrytypes "github.com/docker/docker/api/types/registry"
+	"github.com/gorilla/mux"
+	"github.com/mxy680/meco/apps/api/internal/errdefs"
+	"github.com/mxy680/meco/apps/api/internal/model"
+	"github.com/mxy680/meco/apps/api/internal/registry"
+)
+
+// registryCredentialsFileEnv names a JSON file to persist registry
+// credentials to; unset, the store is in-memory only and forgets
+// credentials across restarts.
+const registryCredentialsFileEnv = "REGISTRY_CREDENTIALS_FILE"
+
+// credentialStore holds the registry credentials the API server pulls
+// with; CreateContainer falls back to it when a caller doesn't
+// supply an X-Registry-Auth header. Callers populate it via
+// RegisterRegistryAuth before pulling from a private registry.
+var credentialStore = newCredentialStore()
+
+// newCredentialStore returns a file-backed store when
+// REGISTRY_CREDENTIALS_FILE is set, so credentials survive a restart,
+// and an in-memory store otherwise.
+func newCredentialStore() registry.Store {
+	if path := os.Getenv(registryCredentialsFileEnv); path != "" {
+		return registry.NewFileStore(path)
+	}
+	return registry.NewInMemoryStore()
+}
+
+// RegisterRegistryAuth handles POST /registries/auth, storing credentials
+// for a registry so later image pulls can use them without an explicit
+// X-Registry-Auth header.
+func RegisterRegistryAuth(w http.ResponseWriter, r *http.Request) {
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	var auth registrytypes.AuthConfig
+	if err := json.NewDecoder(r.Body).Decode(&auth); err != nil {
+		writeError(w, errdefs.InvalidParameter(fmt.Errorf("invalid request body: %w", err)))
+		return
+	}
+	if auth.ServerAddress == "" {
+		writeError(w, errdefs.InvalidParameter(fmt.Errorf("serveraddress is required")))
+		return
+	}
+
+	if err := credentialStore.Store(auth); err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("failed to store registry credentials: %w", err)))
+		return
+	}
+	log.Printf("[INFO] Stored credentials for registry %s", auth.ServerAddress)
+
+	json.NewEncoder(w).Encode(model.StopContainerResponse{OK: true})
+}
+
+// RemoveRegistryAuth handles DELETE /registries/{host}/auth, erasing any
+// stored credentials for a registry.
+func RemoveRegistryAuth(w http.ResponseWriter, r *http.Request) {
+	host := mux.Vars(r)["host"]
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	if err := credentialStore.Erase(host); err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("failed to erase registry credentials: %w", err)))
+		return
+	}
+	log.Printf("[INFO] Erased credentials for registry %s", host)
+
+	json.NewEncoder(w).Encode(model.StopContainerResponse{OK: true})
+}
+
+// resolveRegistryAuth returns the base64-encoded AuthConfig to send as
+// ImagePullOptions.RegistryAuth, preferring an explicit X-Registry-Auth
+// header over credentials already stored for the target registry.
+func resolveRegistryAuth(r *http.Request, host string) (string, error) {
+	if header := r.Header.Get("X-Registry-Auth"); header != "" {
+		if _, err := registrytypes.DecodeAuthConfig(header); err != nil {
+			return "", fmt.Errorf("invalid X-Registry-Auth header: %w", err)
+		}
+		return header, nil
+	}
+
+	auth, err := credentialStore.Get(host)
+	if err != nil {
+		return "", err
+	}
+	if auth == (registrytypes.AuthConfig{}) {
+		return "", nil
+	}
+	return registrytypes.EncodeAuthConfig(auth)
+}
@@ -12,98 +12,337 @@ import (
 
 	"github.com/docker/docker/api/types/container"
 	imageTypes "github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/client"
+	"github.com/gorilla/mux"
+	"github.com/mxy680/meco/apps/api/internal/dockerclient"
+	"github.com/mxy680/meco/apps/api/internal/errdefs"
 	"github.com/mxy680/meco/apps/api/internal/model"
+	"github.com/mxy680/meco/apps/api/internal/reference"
 )
 
-// CreateContainer handles container creation requests.
+// CreateContainer handles POST /containers. The request body selects the
+// image, command, environment, labels, and mounts for the new container.
 func CreateContainer(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
-	if r.Method != http.MethodGet {
-		log.Printf("[WARN] Method not allowed: %s", r.Method)
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+
+	var req model.CreateContainerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errdefs.InvalidParameter(fmt.Errorf("invalid request body: %w", err)))
+		return
+	}
+	if req.Image == "" {
+		writeError(w, errdefs.InvalidParameter(fmt.Errorf("image is required")))
+		return
+	}
+
+	ref, err := reference.Parse(req.Image)
+	if err != nil {
+		writeError(w, errdefs.InvalidParameter(err))
 		return
 	}
-	log.Printf("[INFO] Creating Docker client...")
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithVersion("1.48"))
+
+	// Validate everything that can fail before any bytes go out, since
+	// a streamed response (progress=1) commits its headers on the
+	// first write and can't carry an HTTP error status after that.
+	hostConfig, exposedPorts, err := toHostConfig(req)
 	if err != nil {
-		log.Printf("[ERROR] Docker client error: %v", err)
-		http.Error(w, "docker client error", http.StatusInternalServerError)
+		writeError(w, errdefs.InvalidParameter(err))
+		return
+	}
+
+	cli, err := dockerclient.Get()
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("docker client error: %w", err)))
 		return
 	}
 	ctx := context.Background()
-	image := "mxy680/meco-base:latest"
-	log.Printf("[INFO] Pulling image '%s' if not present...", image)
-	reader, err := cli.ImagePull(ctx, image, imageTypes.PullOptions{})
+
+	auth, err := resolveRegistryAuth(r, ref.Registry)
 	if err != nil {
-		log.Printf("[ERROR] Failed to pull image: %v", err)
-		http.Error(w, "failed to pull image", http.StatusInternalServerError)
+		writeError(w, errdefs.InvalidParameter(err))
+		return
+	}
+
+	log.Printf("[INFO] Pulling image '%s' if not present...", ref.String())
+	reader, err := cli.ImagePull(ctx, ref.String(), imageTypes.PullOptions{RegistryAuth: auth})
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("image pull failed: %w", err)))
 		return
 	}
 	defer reader.Close()
-	io.Copy(io.Discard, reader)
 
-	log.Printf("[INFO] Creating container with image '%s' and default command...", image)
-	// Generate a random hash for container name
+	streaming := r.URL.Query().Get("progress") == "1"
+	var enc *json.Encoder
+	if streaming {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Transfer-Encoding", "chunked")
+		enc = json.NewEncoder(newFlushWriter(w))
+		streamPullProgress(enc, reader)
+	} else {
+		io.Copy(io.Discard, reader)
+	}
+
 	hashBytes := make([]byte, 8)
-	_, err = io.ReadFull(rand.Reader, hashBytes)
-	if err != nil {
-		log.Printf("[ERROR] Failed to generate random hash: %v", err)
-		http.Error(w, "failed to generate container name", http.StatusInternalServerError)
+	if _, err := io.ReadFull(rand.Reader, hashBytes); err != nil {
+		err = fmt.Errorf("failed to generate container name: %w", err)
+		if streaming {
+			writeStreamError(enc, err)
+		} else {
+			writeError(w, errdefs.System(err))
+		}
 		return
 	}
-	containerName := "test-container-" + fmt.Sprintf("%x", hashBytes)
+	containerName := "meco-" + fmt.Sprintf("%x", hashBytes)
 
+	log.Printf("[INFO] Creating container '%s' with image '%s'...", containerName, ref.String())
 	resp, err := cli.ContainerCreate(ctx, &container.Config{
-		Image: image,
-	}, nil, nil, nil, containerName)
+		Image:        ref.String(),
+		Cmd:          req.Cmd,
+		Env:          req.Env,
+		Labels:       req.Labels,
+		ExposedPorts: exposedPorts,
+	}, hostConfig, toNetworkingConfig(req), nil, containerName)
 	if err != nil {
-		log.Printf("[ERROR] Container creation failed: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		err = fmt.Errorf("container creation failed: %w", err)
+		if streaming {
+			writeStreamError(enc, err)
+		} else {
+			writeError(w, errdefs.System(err))
+		}
 		return
 	}
 	log.Printf("[INFO] Container created successfully, ID: %s", resp.ID)
 
-	// Start the container after creation
 	if err := cli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
-		log.Printf("[ERROR] Failed to start container: %v", err)
-		http.Error(w, "failed to start container", http.StatusInternalServerError)
+		err = fmt.Errorf("failed to start container: %w", err)
+		if streaming {
+			writeStreamError(enc, err)
+		} else {
+			writeError(w, errdefs.System(err))
+		}
 		return
 	}
 	log.Printf("[INFO] Container started successfully, ID: %s", resp.ID)
 
-	if err := json.NewEncoder(w).Encode(model.CreateContainerResponse{ID: resp.ID}); err != nil {
+	if streaming {
+		writeStreamResult(enc, resp.ID, resp.Warnings)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(model.CreateContainerResponse{ID: resp.ID, Warnings: resp.Warnings}); err != nil {
 		log.Printf("[ERROR] Failed to encode response: %v", err)
 	}
 }
 
-// StopContainer handles container stop requests.
-func StopContainer(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+// ListContainers handles GET /containers?filter=key=value, listing both
+// running and stopped containers. Filters use the same repeated
+// filter=key=value query params as GET /events (e.g. filter=status=running,
+// filter=label=env=prod).
+func ListContainers(w http.ResponseWriter, r *http.Request) {
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	filterArgs, err := parseFilterParams(r.URL.Query())
+	if err != nil {
+		writeError(w, errdefs.InvalidParameter(err))
+		return
+	}
+
+	cli, err := dockerclient.Get()
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("docker client error: %w", err)))
 		return
 	}
-	containerID := r.URL.Query().Get("id")
-	if containerID == "" {
-		http.Error(w, "Missing container id", http.StatusBadRequest)
+
+	containers, err := cli.ContainerList(r.Context(), container.ListOptions{All: true, Filters: filterArgs})
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("failed to list containers: %w", err)))
 		return
 	}
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithVersion("1.48"))
+
+	summaries := make([]model.ContainerSummary, 0, len(containers))
+	for _, c := range containers {
+		summaries = append(summaries, model.ContainerSummary{
+			ID:      c.ID,
+			Names:   c.Names,
+			Image:   c.Image,
+			State:   c.State,
+			Status:  c.Status,
+			Created: c.Created,
+		})
+	}
+
+	if err := json.NewEncoder(w).Encode(summaries); err != nil {
+		log.Printf("[ERROR] Failed to encode response: %v", err)
+	}
+}
+
+// InspectContainer handles GET /containers/{id}.
+func InspectContainer(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	cli, err := dockerclient.Get()
 	if err != nil {
-		log.Printf("[ERROR] Docker client error: %v", err)
-		json.NewEncoder(w).Encode(map[string]bool{"ok": false})
+		writeError(w, errdefs.System(fmt.Errorf("docker client error: %w", err)))
 		return
 	}
-	ctx := context.Background()
-	timeout := 10 * time.Second
-	seconds := int(timeout.Seconds())
-	stopOptions := container.StopOptions{Timeout: &seconds}
-	err = cli.ContainerStop(ctx, containerID, stopOptions)
+
+	info, err := cli.ContainerInspect(r.Context(), id)
+	if err != nil {
+		writeError(w, notFoundOrSystem(id, err))
+		return
+	}
+
+	created, _ := time.Parse(time.RFC3339Nano, info.Created)
+	resp := model.ContainerInspect{
+		ID:      info.ID,
+		Name:    info.Name,
+		Image:   info.Config.Image,
+		State:   info.State.Status,
+		Created: created,
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] Failed to encode response: %v", err)
+	}
+}
+
+// RemoveContainer handles DELETE /containers/{id}.
+func RemoveContainer(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	cli, err := dockerclient.Get()
 	if err != nil {
-		log.Printf("[ERROR] Failed to stop container %s: %v", containerID, err)
-		json.NewEncoder(w).Encode(map[string]bool{"ok": false})
+		writeError(w, errdefs.System(fmt.Errorf("docker client error: %w", err)))
 		return
 	}
-	log.Printf("[INFO] Container %s stopped successfully", containerID)
+
+	force := r.URL.Query().Get("force") == "true"
+	if err := cli.ContainerRemove(r.Context(), id, container.RemoveOptions{Force: force}); err != nil {
+		writeError(w, notFoundOrSystem(id, err))
+		return
+	}
+	log.Printf("[INFO] Container %s removed successfully", id)
+	json.NewEncoder(w).Encode(model.StopContainerResponse{OK: true})
+}
+
+// RestartContainer handles POST /containers/{id}/restart.
+func RestartContainer(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	cli, err := dockerclient.Get()
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("docker client error: %w", err)))
+		return
+	}
+
+	if err := cli.ContainerRestart(r.Context(), id, container.StopOptions{}); err != nil {
+		writeError(w, notFoundOrSystem(id, err))
+		return
+	}
+	log.Printf("[INFO] Container %s restarted successfully", id)
+	json.NewEncoder(w).Encode(model.StopContainerResponse{OK: true})
+}
+
+// KillContainer handles POST /containers/{id}/kill.
+func KillContainer(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	signal := r.URL.Query().Get("signal")
+	if signal == "" {
+		signal = "SIGKILL"
+	}
+
+	cli, err := dockerclient.Get()
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("docker client error: %w", err)))
+		return
+	}
+
+	if err := cli.ContainerKill(r.Context(), id, signal); err != nil {
+		writeError(w, notFoundOrSystem(id, err))
+		return
+	}
+	log.Printf("[INFO] Container %s killed successfully", id)
 	json.NewEncoder(w).Encode(model.StopContainerResponse{OK: true})
 }
+
+// StopContainer handles POST /containers/{id}/stop.
+func StopContainer(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	cli, err := dockerclient.Get()
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("docker client error: %w", err)))
+		return
+	}
+
+	timeout := 10
+	if err := cli.ContainerStop(r.Context(), id, container.StopOptions{Timeout: &timeout}); err != nil {
+		writeError(w, notFoundOrSystem(id, err))
+		return
+	}
+	log.Printf("[INFO] Container %s stopped successfully", id)
+	json.NewEncoder(w).Encode(model.StopContainerResponse{OK: true})
+}
+
+// WaitContainer handles POST /containers/{id}/wait, blocking until the
+// container exits.
+func WaitContainer(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	cli, err := dockerclient.Get()
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("docker client error: %w", err)))
+		return
+	}
+
+	statusCh, errCh := cli.ContainerWait(r.Context(), id, container.WaitConditionNotRunning)
+	select {
+	case err := <-errCh:
+		if err != nil {
+			writeError(w, notFoundOrSystem(id, err))
+			return
+		}
+	case status := <-statusCh:
+		resp := model.WaitResponse{StatusCode: status.StatusCode}
+		if status.Error != nil {
+			resp.Error = status.Error.Message
+		}
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// toMounts converts request-level mount descriptions into Docker's mount
+// type, rejecting unsupported mount types.
+func toMounts(reqMounts []model.MountRequest) ([]mount.Mount, error) {
+	mounts := make([]mount.Mount, 0, len(reqMounts))
+	for _, m := range reqMounts {
+		switch mount.Type(m.Type) {
+		case mount.TypeBind, mount.TypeVolume:
+		default:
+			return nil, fmt.Errorf("unsupported mount type %q", m.Type)
+		}
+		mounts = append(mounts, mount.Mount{
+			Type:     mount.Type(m.Type),
+			Source:   m.Source,
+			Target:   m.Target,
+			ReadOnly: m.ReadOnly,
+		})
+	}
+	return mounts, nil
+}
+
+// notFoundOrSystem classifies a Docker API error for container id as a
+// 404 when the daemon reports the container doesn't exist, falling back
+// to a generic system error otherwise.
+func notFoundOrSystem(id string, err error) error {
+	if client.IsErrNotFound(err) {
+		return errdefs.NotFound(fmt.Errorf("container %s not found: %w", id, err))
+	}
+	return errdefs.System(err)
+}
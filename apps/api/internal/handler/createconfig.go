@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"github.com/mxy680/meco/apps/api/internal/model"
+)
+
+// toHostConfig maps the resource, networking, and mount fields of a
+// CreateContainerRequest onto a container.HostConfig, the same surface
+// the docker CLI's runconfigopts package assembles from run flags. It
+// also returns the exposed-port set that belongs on container.Config.
+func toHostConfig(req model.CreateContainerRequest) (*container.HostConfig, nat.PortSet, error) {
+	mounts, err := toMounts(req.Mounts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	portBindings, exposedPorts, err := toPortBindings(req.Ports)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	restartPolicy, err := toRestartPolicy(req.RestartPolicy, req.RestartMaxRetries)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ulimits []*container.Ulimit
+	for _, u := range req.Resources.Ulimits {
+		ulimits = append(ulimits, &container.Ulimit{Name: u.Name, Soft: u.Soft, Hard: u.Hard})
+	}
+
+	hc := &container.HostConfig{
+		Mounts:          mounts,
+		PortBindings:    portBindings,
+		PublishAllPorts: req.PublishAllPorts,
+		RestartPolicy:   restartPolicy,
+		CapAdd:          req.CapAdd,
+		CapDrop:         req.CapDrop,
+		SecurityOpt:     req.SecurityOpt,
+		ReadonlyRootfs:  req.ReadonlyRootfs,
+		Tmpfs:           req.Tmpfs,
+		Resources: container.Resources{
+			CPUShares:  req.Resources.CPUShares,
+			CPUQuota:   req.Resources.CPUQuota,
+			CPUPeriod:  req.Resources.CPUPeriod,
+			CpusetCpus: req.Resources.CpusetCPUs,
+			Memory:     req.Resources.Memory,
+			MemorySwap: req.Resources.MemorySwap,
+			PidsLimit:  req.Resources.PidsLimit,
+			Ulimits:    ulimits,
+		},
+	}
+	if req.NetworkMode != "" {
+		hc.NetworkMode = container.NetworkMode(req.NetworkMode)
+	}
+	return hc, exposedPorts, nil
+}
+
+// toNetworkingConfig maps requested network attachments onto the
+// container.NetworkingConfig passed to ContainerCreate, which is how
+// containers join user-defined networks at create time.
+func toNetworkingConfig(req model.CreateContainerRequest) *network.NetworkingConfig {
+	if len(req.Networks) == 0 {
+		return nil
+	}
+	endpoints := make(map[string]*network.EndpointSettings, len(req.Networks))
+	for _, n := range req.Networks {
+		endpoints[n.Name] = &network.EndpointSettings{Aliases: n.Aliases}
+	}
+	return &network.NetworkingConfig{EndpointsConfig: endpoints}
+}
+
+// toPortBindings converts requested port bindings into go-connections'
+// nat.PortMap, plus the matching set of exposed ports for
+// container.Config.
+func toPortBindings(reqPorts []model.PortBinding) (nat.PortMap, nat.PortSet, error) {
+	bindings := make(nat.PortMap, len(reqPorts))
+	exposed := make(nat.PortSet, len(reqPorts))
+	for _, p := range reqPorts {
+		proto := p.Protocol
+		if proto == "" {
+			proto = "tcp"
+		}
+		port, err := nat.NewPort(proto, p.ContainerPort)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid port %q: %w", p.ContainerPort, err)
+		}
+		exposed[port] = struct{}{}
+		bindings[port] = append(bindings[port], nat.PortBinding{HostIP: p.HostIP, HostPort: p.HostPort})
+	}
+	return bindings, exposed, nil
+}
+
+// toRestartPolicy validates and converts the requested restart policy
+// name into container.RestartPolicy.
+func toRestartPolicy(name string, maxRetries int) (container.RestartPolicy, error) {
+	if name == "" {
+		return container.RestartPolicy{}, nil
+	}
+	switch container.RestartPolicyMode(name) {
+	case container.RestartPolicyDisabled, container.RestartPolicyAlways,
+		container.RestartPolicyOnFailure, container.RestartPolicyUnlessStopped:
+	default:
+		return container.RestartPolicy{}, fmt.Errorf("unsupported restart policy %q", name)
+	}
+	return container.RestartPolicy{
+		Name:              container.RestartPolicyMode(name),
+		MaximumRetryCount: maxRetries,
+	}, nil
+}
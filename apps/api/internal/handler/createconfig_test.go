@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/mxy680/meco/apps/api/internal/model"
+)
+
+func TestToPortBindings(t *testing.T) {
+	bindings, exposed, err := toPortBindings([]model.PortBinding{
+		{ContainerPort: "8080", HostPort: "80"},
+		{ContainerPort: "9000", Protocol: "udp", HostIP: "127.0.0.1", HostPort: "9000"},
+	})
+	if err != nil {
+		t.Fatalf("toPortBindings: %v", err)
+	}
+
+	tcpPort := nat.Port("8080/tcp")
+	if _, ok := exposed[tcpPort]; !ok {
+		t.Fatalf("exposed ports missing %s: %+v", tcpPort, exposed)
+	}
+	if got := bindings[tcpPort]; len(got) != 1 || got[0].HostPort != "80" {
+		t.Fatalf("bindings[%s] = %+v, want HostPort 80", tcpPort, got)
+	}
+
+	udpPort := nat.Port("9000/udp")
+	if got := bindings[udpPort]; len(got) != 1 || got[0].HostIP != "127.0.0.1" || got[0].HostPort != "9000" {
+		t.Fatalf("bindings[%s] = %+v, want HostIP 127.0.0.1 HostPort 9000", udpPort, got)
+	}
+}
+
+func TestToPortBindingsDefaultsProtocolToTCP(t *testing.T) {
+	_, exposed, err := toPortBindings([]model.PortBinding{{ContainerPort: "80"}})
+	if err != nil {
+		t.Fatalf("toPortBindings: %v", err)
+	}
+	if _, ok := exposed[nat.Port("80/tcp")]; !ok {
+		t.Fatalf("expected 80/tcp in exposed ports, got %+v", exposed)
+	}
+}
+
+func TestToPortBindingsInvalidPort(t *testing.T) {
+	if _, _, err := toPortBindings([]model.PortBinding{{ContainerPort: "not-a-port"}}); err == nil {
+		t.Fatal("expected an error for an invalid container port")
+	}
+}
+
+func TestToRestartPolicyEmptyIsZeroValue(t *testing.T) {
+	policy, err := toRestartPolicy("", 0)
+	if err != nil {
+		t.Fatalf("toRestartPolicy: %v", err)
+	}
+	if policy != (container.RestartPolicy{}) {
+		t.Fatalf("toRestartPolicy(\"\", 0) = %+v, want zero value", policy)
+	}
+}
+
+func TestToRestartPolicyValidModes(t *testing.T) {
+	for _, mode := range []container.RestartPolicyMode{
+		container.RestartPolicyDisabled,
+		container.RestartPolicyAlways,
+		container.RestartPolicyOnFailure,
+		container.RestartPolicyUnlessStopped,
+	} {
+		t.Run(string(mode), func(t *testing.T) {
+			policy, err := toRestartPolicy(string(mode), 3)
+			if err != nil {
+				t.Fatalf("toRestartPolicy(%q): %v", mode, err)
+			}
+			if policy.Name != mode || policy.MaximumRetryCount != 3 {
+				t.Fatalf("toRestartPolicy(%q) = %+v, want Name=%s MaximumRetryCount=3", mode, policy, mode)
+			}
+		})
+	}
+}
+
+func TestToRestartPolicyRejectsUnsupportedMode(t *testing.T) {
+	if _, err := toRestartPolicy("bogus", 0); err == nil {
+		t.Fatal("expected an error for an unsupported restart policy")
+	}
+}
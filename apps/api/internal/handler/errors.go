@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/mxy680/meco/apps/api/internal/errdefs"
+)
+
+// errorEnvelope is the uniform JSON shape returned for every handler
+// error.
+type errorEnvelope struct {
+	Message string `json:"message"`
+}
+
+// writeError maps err to an HTTP status via the errdefs taxonomy and
+// writes the uniform error envelope. Errors that don't opt into a
+// category fall back to 500.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	var notFound errdefs.ErrNotFound
+	var invalidParam errdefs.ErrInvalidParameter
+	var conflict errdefs.ErrConflict
+	var unauthorized errdefs.ErrUnauthorized
+	var notModified errdefs.ErrNotModified
+	var system errdefs.ErrSystem
+
+	switch {
+	case errors.As(err, &notFound) && notFound.NotFound():
+		status = http.StatusNotFound
+	case errors.As(err, &invalidParam) && invalidParam.InvalidParameter():
+		status = http.StatusBadRequest
+	case errors.As(err, &conflict) && conflict.Conflict():
+		status = http.StatusConflict
+	case errors.As(err, &unauthorized) && unauthorized.Unauthorized():
+		status = http.StatusUnauthorized
+	case errors.As(err, &notModified) && notModified.NotModified():
+		status = http.StatusNotModified
+	case errors.As(err, &system) && system.System():
+		status = http.StatusInternalServerError
+	}
+
+	log.Printf("[ERROR] %s", err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(errorEnvelope{Message: err.Error()}); err != nil {
+		log.Printf("[ERROR] Failed to encode error response: %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mxy680/meco/apps/api/internal/errdefs"
+)
+
+func TestWriteErrorMapsCategoryToStatus(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"NotFound", errdefs.NotFound(errors.New("missing")), http.StatusNotFound},
+		{"InvalidParameter", errdefs.InvalidParameter(errors.New("bad input")), http.StatusBadRequest},
+		{"Conflict", errdefs.Conflict(errors.New("conflict")), http.StatusConflict},
+		{"Unauthorized", errdefs.Unauthorized(errors.New("nope")), http.StatusUnauthorized},
+		{"NotModified", errdefs.NotModified(errors.New("unchanged")), http.StatusNotModified},
+		{"System", errdefs.System(errors.New("boom")), http.StatusInternalServerError},
+		{"Uncategorized", errors.New("plain error"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			writeError(rec, tc.err)
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/mxy680/meco/apps/api/internal/dockerclient"
+	"github.com/mxy680/meco/apps/api/internal/errdefs"
+	"github.com/mxy680/meco/apps/api/internal/eventbus"
+)
+
+const eventsKeepaliveInterval = 15 * time.Second
+
+var (
+	busOnce sync.Once
+	bus     *eventbus.Bus
+)
+
+// Events handles GET /events, subscribing to the Docker daemon's event
+// stream and republishing it to the client as Server-Sent Events.
+// Query params: since, until (unix timestamps) and repeated
+// filter=key=value pairs (e.g. filter=type=container, filter=event=start).
+func Events(w http.ResponseWriter, r *http.Request) {
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, errdefs.System(fmt.Errorf("streaming unsupported by this connection")))
+		return
+	}
+
+	filterArgs, err := parseFilterParams(r.URL.Query())
+	if err != nil {
+		writeError(w, errdefs.InvalidParameter(err))
+		return
+	}
+	since := parseUnixTime(r.URL.Query().Get("since"))
+	until := parseUnixTime(r.URL.Query().Get("until"))
+
+	cli, err := dockerclient.Get()
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("docker client error: %w", err)))
+		return
+	}
+
+	msgs, cancel := sharedBus(cli).Subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(eventsKeepaliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg, ok := <-msgs:
+			if !ok {
+				return
+			}
+			if !matchesEventFilters(msg, filterArgs, since, until) {
+				continue
+			}
+			data, err := json.Marshal(msg)
+			if err != nil {
+				log.Printf("[ERROR] Failed to encode event: %v", err)
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		case <-ticker.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// sharedBus lazily creates the process-wide event bus on first use.
+func sharedBus(cli *client.Client) *eventbus.Bus {
+	busOnce.Do(func() { bus = eventbus.New(cli) })
+	return bus
+}
+
+// parseFilterParams turns repeated filter=key=value query params into
+// filters.Args, the shape the Docker API's Filters options expect.
+// Shared by GET /events and GET /containers.
+func parseFilterParams(q url.Values) (filters.Args, error) {
+	args := filters.NewArgs()
+	for _, f := range q["filter"] {
+		key, value, ok := strings.Cut(f, "=")
+		if !ok {
+			return args, fmt.Errorf("invalid filter %q: expected key=value", f)
+		}
+		args.Add(key, value)
+	}
+	return args, nil
+}
+
+// matchesEventFilters reports whether msg satisfies the since/until
+// bounds and the caller-supplied type, event and container filters.
+// Filters on any other key are not applied and match every event.
+func matchesEventFilters(msg events.Message, args filters.Args, since, until int64) bool {
+	if since != 0 && msg.Time < since {
+		return false
+	}
+	if until != 0 && msg.Time > until {
+		return false
+	}
+	return args.Match("type", string(msg.Type)) &&
+		args.Match("event", string(msg.Action)) &&
+		args.Match("container", msg.Actor.ID)
+}
+
+// parseUnixTime returns 0 when s is empty or not a valid integer,
+// meaning "no bound".
+func parseUnixTime(s string) int64 {
+	if s == "" {
+		return 0
+	}
+	t, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return t
+}
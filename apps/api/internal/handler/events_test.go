@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/docker/docker/api/types/events"
+)
+
+func TestParseFilterParams(t *testing.T) {
+	args, err := parseFilterParams(url.Values{"filter": {"type=container", "event=start"}})
+	if err != nil {
+		t.Fatalf("parseFilterParams: %v", err)
+	}
+	if !args.Match("type", "container") {
+		t.Fatal("expected filters to match type=container")
+	}
+	if !args.Match("event", "start") {
+		t.Fatal("expected filters to match event=start")
+	}
+	if args.Match("type", "network") {
+		t.Fatal("expected filters not to match type=network")
+	}
+}
+
+func TestParseFilterParamsInvalid(t *testing.T) {
+	if _, err := parseFilterParams(url.Values{"filter": {"no-equals-sign"}}); err == nil {
+		t.Fatal("expected an error for a filter without key=value")
+	}
+}
+
+func TestMatchesEventFilters(t *testing.T) {
+	msg := events.Message{
+		Type:   events.ContainerEventType,
+		Action: "start",
+		Time:   1000,
+		Actor:  events.Actor{ID: "abc123"},
+	}
+
+	args, err := parseFilterParams(url.Values{"filter": {"type=container"}})
+	if err != nil {
+		t.Fatalf("parseFilterParams: %v", err)
+	}
+
+	if !matchesEventFilters(msg, args, 0, 0) {
+		t.Fatal("expected msg to match with no time bounds")
+	}
+	if matchesEventFilters(msg, args, 1001, 0) {
+		t.Fatal("expected msg to be excluded by a since bound after its time")
+	}
+	if matchesEventFilters(msg, args, 0, 999) {
+		t.Fatal("expected msg to be excluded by an until bound before its time")
+	}
+
+	wrongType, err := parseFilterParams(url.Values{"filter": {"type=network"}})
+	if err != nil {
+		t.Fatalf("parseFilterParams: %v", err)
+	}
+	if matchesEventFilters(msg, wrongType, 0, 0) {
+		t.Fatal("expected msg not to match a filter for a different event type")
+	}
+}
+
+func TestParseUnixTime(t *testing.T) {
+	if got := parseUnixTime(""); got != 0 {
+		t.Fatalf("parseUnixTime(\"\") = %d, want 0", got)
+	}
+	if got := parseUnixTime("not-a-number"); got != 0 {
+		t.Fatalf("parseUnixTime(\"not-a-number\") = %d, want 0", got)
+	}
+	if got := parseUnixTime("12345"); got != 12345 {
+		t.Fatalf("parseUnixTime(\"12345\") = %d, want 12345", got)
+	}
+}
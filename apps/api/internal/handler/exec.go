@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/gorilla/mux"
+	"github.com/mxy680/meco/apps/api/internal/dockerclient"
+	"github.com/mxy680/meco/apps/api/internal/errdefs"
+	"github.com/mxy680/meco/apps/api/internal/model"
+)
+
+// ExecCreate handles POST /containers/{id}/exec, creating an exec
+// instance without running it.
+func ExecCreate(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	var req model.ExecCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errdefs.InvalidParameter(fmt.Errorf("invalid request body: %w", err)))
+		return
+	}
+	if len(req.Cmd) == 0 {
+		writeError(w, errdefs.InvalidParameter(fmt.Errorf("cmd is required")))
+		return
+	}
+
+	cli, err := dockerclient.Get()
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("docker client error: %w", err)))
+		return
+	}
+
+	resp, err := cli.ContainerExecCreate(r.Context(), id, container.ExecOptions{
+		Cmd:          req.Cmd,
+		Env:          req.Env,
+		Tty:          req.Tty,
+		AttachStdin:  req.AttachStdin,
+		AttachStdout: req.AttachStdout,
+		AttachStderr: req.AttachStderr,
+	})
+	if err != nil {
+		writeError(w, notFoundOrSystem(id, err))
+		return
+	}
+	log.Printf("[INFO] Exec %s created for container %s", resp.ID, id)
+
+	json.NewEncoder(w).Encode(model.ExecCreateResponse{ID: resp.ID})
+}
+
+// ExecStart handles POST /exec/{id}/start?tty=1, hijacking the HTTP
+// connection (as Attach does) and pumping raw bytes in both directions
+// between the client and the exec's stdio. The tty query parameter must
+// match the Tty value the exec was created with: a non-tty exec
+// multiplexes stdout/stderr behind stdcopy's frame headers and needs
+// demultiplexing, while a tty exec is a single raw stream.
+func ExecStart(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		writeError(w, errdefs.System(fmt.Errorf("connection does not support hijacking")))
+		return
+	}
+
+	cli, err := dockerclient.Get()
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("docker client error: %w", err)))
+		return
+	}
+
+	tty := r.URL.Query().Get("tty") == "1"
+	attach, err := cli.ContainerExecAttach(r.Context(), id, container.ExecStartOptions{Tty: tty})
+	if err != nil {
+		writeError(w, notFoundOrSystem(id, err))
+		return
+	}
+	defer attach.Close()
+
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("failed to hijack connection: %w", err)))
+		return
+	}
+	defer conn.Close()
+
+	fmt.Fprint(conn, "HTTP/1.1 200 OK\r\nContent-Type: application/vnd.docker.raw-stream\r\n\r\n")
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(attach.Conn, buf)
+		done <- struct{}{}
+	}()
+	go func() {
+		if tty {
+			io.Copy(conn, attach.Reader)
+		} else if _, err := stdcopy.StdCopy(conn, conn, attach.Reader); err != nil {
+			log.Printf("[ERROR] Failed to demux exec %s output: %v", id, err)
+		}
+		done <- struct{}{}
+	}()
+	<-done
+
+	log.Printf("[INFO] Exec %s session closed", id)
+}
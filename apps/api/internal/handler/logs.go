@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/gorilla/mux"
+	"github.com/mxy680/meco/apps/api/internal/dockerclient"
+	"github.com/mxy680/meco/apps/api/internal/errdefs"
+)
+
+// ContainerLogs handles GET /containers/{id}/logs?follow=1&stdout=1&stderr=1&tail=N,
+// demultiplexing Docker's stdcopy framing and streaming the result to
+// the client as it arrives.
+func ContainerLogs(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	cli, err := dockerclient.Get()
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("docker client error: %w", err)))
+		return
+	}
+
+	q := r.URL.Query()
+	tail := q.Get("tail")
+	if tail == "" {
+		tail = "all"
+	}
+	follow := q.Get("follow") == "1"
+
+	reader, err := cli.ContainerLogs(r.Context(), id, container.LogsOptions{
+		ShowStdout: q.Get("stdout") != "0",
+		ShowStderr: q.Get("stderr") != "0",
+		Follow:     follow,
+		Tail:       tail,
+	})
+	if err != nil {
+		writeError(w, notFoundOrSystem(id, err))
+		return
+	}
+	defer reader.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if follow {
+		w.Header().Set("Transfer-Encoding", "chunked")
+	}
+
+	fw := newFlushWriter(w)
+	if _, err := stdcopy.StdCopy(fw, fw, reader); err != nil {
+		log.Printf("[ERROR] Failed to stream logs for container %s: %v", id, err)
+	}
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+// pullStreamEvent is one line of the newline-delimited JSON body
+// produced by CreateContainer's ?progress=1 mode. The stream carries
+// zero or more "progress" events followed by exactly one terminal
+// "created" or "error" event. This lets a client tell a successful
+// create apart from a failure that happened after the response's
+// headers were already committed by the first progress write, at
+// which point an HTTP error status can no longer be sent.
+type pullStreamEvent struct {
+	Event    string                   `json:"event"`
+	Message  *jsonmessage.JSONMessage `json:"message,omitempty"`
+	ID       string                   `json:"id,omitempty"`
+	Warnings []string                 `json:"warnings,omitempty"`
+	Error    string                   `json:"error,omitempty"`
+}
+
+// streamPullProgress decodes Docker's image pull JSON stream and
+// re-emits each message as a "progress" event, flushing after every
+// message so a caller can render per-layer progress bars as the pull
+// happens rather than after it completes.
+func streamPullProgress(enc *json.Encoder, reader io.Reader) {
+	dec := json.NewDecoder(reader)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := dec.Decode(&msg); err != nil {
+			if err != io.EOF {
+				log.Printf("[ERROR] Failed to decode pull progress: %v", err)
+			}
+			return
+		}
+		if err := enc.Encode(pullStreamEvent{Event: "progress", Message: &msg}); err != nil {
+			log.Printf("[ERROR] Failed to stream pull progress: %v", err)
+			return
+		}
+	}
+}
+
+// writeStreamResult emits the terminal "created" event, reporting the
+// ID of the container that was created and started successfully.
+func writeStreamResult(enc *json.Encoder, id string, warnings []string) {
+	if err := enc.Encode(pullStreamEvent{Event: "created", ID: id, Warnings: warnings}); err != nil {
+		log.Printf("[ERROR] Failed to stream create result: %v", err)
+	}
+}
+
+// writeStreamError emits the terminal "error" event for a failure that
+// happened after progress streaming had already started, and so can no
+// longer be reported via the HTTP status line.
+func writeStreamError(enc *json.Encoder, err error) {
+	log.Printf("[ERROR] %s", err)
+	if encErr := enc.Encode(pullStreamEvent{Event: "error", Error: err.Error()}); encErr != nil {
+		log.Printf("[ERROR] Failed to stream error result: %v", encErr)
+	}
+}
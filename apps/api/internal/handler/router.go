@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// NewRouter wires every container lifecycle endpoint onto a gorilla/mux
+// router, ready to be mounted by the API server's entrypoint.
+func NewRouter() *mux.Router {
+	r := mux.NewRouter()
+
+	r.HandleFunc("/containers", ListContainers).Methods(http.MethodGet)
+	r.HandleFunc("/containers", CreateContainer).Methods(http.MethodPost)
+	r.HandleFunc("/containers/{id}", InspectContainer).Methods(http.MethodGet)
+	r.HandleFunc("/containers/{id}", RemoveContainer).Methods(http.MethodDelete)
+	r.HandleFunc("/containers/{id}/logs", ContainerLogs).Methods(http.MethodGet)
+	r.HandleFunc("/containers/{id}/attach", Attach).Methods(http.MethodPost)
+	r.HandleFunc("/containers/{id}/attach/ws", ContainerAttachWS).Methods(http.MethodGet)
+	r.HandleFunc("/containers/{id}/restart", RestartContainer).Methods(http.MethodPost)
+	r.HandleFunc("/containers/{id}/kill", KillContainer).Methods(http.MethodPost)
+	r.HandleFunc("/containers/{id}/stop", StopContainer).Methods(http.MethodPost)
+	r.HandleFunc("/containers/{id}/wait", WaitContainer).Methods(http.MethodPost)
+	r.HandleFunc("/containers/{id}/exec", ExecCreate).Methods(http.MethodPost)
+	r.HandleFunc("/exec/{id}/start", ExecStart).Methods(http.MethodPost)
+	r.HandleFunc("/exec/{id}/ws", ExecWS).Methods(http.MethodGet)
+	r.HandleFunc("/events", Events).Methods(http.MethodGet)
+	r.HandleFunc("/registries/auth", RegisterRegistryAuth).Methods(http.MethodPost)
+	r.HandleFunc("/registries/{host}/auth", RemoveRegistryAuth).Methods(http.MethodDelete)
+
+	return r
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+)
+
+// flushWriter wraps an http.ResponseWriter so that every Write is
+// flushed to the client immediately, instead of waiting for the
+// handler to return. This is what lets pull progress and log tails
+// arrive incrementally rather than all at once.
+type flushWriter struct {
+	w io.Writer
+	f http.Flusher
+}
+
+func newFlushWriter(w http.ResponseWriter) *flushWriter {
+	f, _ := w.(http.Flusher)
+	return &flushWriter{w: w, f: f}
+}
+
+func (fw *flushWriter) Write(p []byte) (int, error) {
+	n, err := fw.w.Write(p)
+	if fw.f != nil {
+		fw.f.Flush()
+	}
+	return n, err
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/mxy680/meco/apps/api/internal/dockerclient"
+	"github.com/mxy680/meco/apps/api/internal/errdefs"
+)
+
+const (
+	wsPingInterval = 30 * time.Second
+	wsIdleTimeout  = 60 * time.Second
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// resizeMessage is the JSON control frame a client sends over the
+// WebSocket to resize the remote TTY.
+type resizeMessage struct {
+	Cols uint `json:"cols"`
+	Rows uint `json:"rows"`
+}
+
+// ContainerAttachWS handles GET /containers/{id}/attach/ws. It creates a
+// TTY-enabled exec session in the container (defaulting to /bin/sh) and
+// proxies it to the browser over a WebSocket, for a web shell UI.
+func ContainerAttachWS(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	shell := r.URL.Query().Get("cmd")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+
+	cli, err := dockerclient.Get()
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("docker client error: %w", err)))
+		return
+	}
+
+	exec, err := cli.ContainerExecCreate(r.Context(), id, container.ExecOptions{
+		Cmd:          []string{shell},
+		Tty:          true,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		writeError(w, notFoundOrSystem(id, err))
+		return
+	}
+
+	serveExecWS(w, r, cli, exec.ID)
+}
+
+// ExecWS handles GET /exec/{id}/ws, attaching to a previously created
+// exec instance and proxying it to the browser over a WebSocket.
+func ExecWS(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	log.Printf("[INFO] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	cli, err := dockerclient.Get()
+	if err != nil {
+		writeError(w, errdefs.System(fmt.Errorf("docker client error: %w", err)))
+		return
+	}
+
+	serveExecWS(w, r, cli, id)
+}
+
+// serveExecWS attaches to execID, upgrades the connection to a
+// WebSocket, and pumps bytes in both directions until either side
+// closes: binary frames carry TTY data, text frames carry resize
+// control messages.
+func serveExecWS(w http.ResponseWriter, r *http.Request, cli *client.Client, execID string) {
+	hijacked, err := cli.ContainerExecAttach(r.Context(), execID, container.ExecStartOptions{Tty: true})
+	if err != nil {
+		writeError(w, notFoundOrSystem(execID, err))
+		return
+	}
+	defer hijacked.Close()
+
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("[ERROR] Failed to upgrade websocket for exec %s: %v", execID, err)
+		return
+	}
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(wsIdleTimeout))
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(wsIdleTimeout))
+		return nil
+	})
+
+	var stopOnce sync.Once
+	done := make(chan struct{})
+	stop := func() { stopOnce.Do(func() { close(done) }) }
+
+	// gorilla/websocket allows only one concurrent writer per connection;
+	// the daemon->browser pump below and the ping ticker both write, so
+	// they must serialize through this mutex or a racing write panics
+	// the whole process, not just this session.
+	var writeMu sync.Mutex
+
+	// daemon -> browser
+	go func() {
+		defer stop()
+		buf := make([]byte, 4096)
+		for {
+			n, err := hijacked.Reader.Read(buf)
+			if n > 0 {
+				writeMu.Lock()
+				writeErr := conn.WriteMessage(websocket.BinaryMessage, buf[:n])
+				writeMu.Unlock()
+				if writeErr != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	// browser -> daemon, including resize control frames
+	go func() {
+		defer stop()
+		for {
+			msgType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			switch msgType {
+			case websocket.BinaryMessage:
+				if _, err := hijacked.Conn.Write(data); err != nil {
+					return
+				}
+			case websocket.TextMessage:
+				var resize resizeMessage
+				if err := json.Unmarshal(data, &resize); err != nil {
+					log.Printf("[WARN] Ignoring malformed resize frame for exec %s: %v", execID, err)
+					continue
+				}
+				if err := cli.ContainerExecResize(r.Context(), execID, container.ResizeOptions{
+					Height: resize.Rows,
+					Width:  resize.Cols,
+				}); err != nil {
+					log.Printf("[WARN] Failed to resize exec %s: %v", execID, err)
+				}
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			log.Printf("[INFO] WebSocket session for exec %s closed", execID)
+			return
+		case <-ticker.C:
+			writeMu.Lock()
+			err := conn.WriteMessage(websocket.PingMessage, nil)
+			writeMu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package model
+
+import "time"
+
+// MountRequest describes a single bind or volume mount to attach to a
+// container at create time.
+type MountRequest struct {
+	Type     string `json:"type"`
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	ReadOnly bool   `json:"readOnly,omitempty"`
+}
+
+// Ulimit sets a single soft/hard resource limit inside the container.
+type Ulimit struct {
+	Name string `json:"name"`
+	Soft int64  `json:"soft"`
+	Hard int64  `json:"hard"`
+}
+
+// ResourceLimits mirrors the cgroup limits the docker CLI's run flags
+// expose (--cpus, --memory, --pids-limit, --ulimit, ...).
+type ResourceLimits struct {
+	CPUShares  int64    `json:"cpuShares,omitempty"`
+	CPUQuota   int64    `json:"cpuQuota,omitempty"`
+	CPUPeriod  int64    `json:"cpuPeriod,omitempty"`
+	CpusetCPUs string   `json:"cpusetCpus,omitempty"`
+	Memory     int64    `json:"memory,omitempty"`
+	MemorySwap int64    `json:"memorySwap,omitempty"`
+	PidsLimit  *int64   `json:"pidsLimit,omitempty"`
+	Ulimits    []Ulimit `json:"ulimits,omitempty"`
+}
+
+// PortBinding maps a container port to a port on the host, mirroring
+// the docker CLI's -p flag.
+type PortBinding struct {
+	ContainerPort string `json:"containerPort"`
+	Protocol      string `json:"protocol,omitempty"`
+	HostIP        string `json:"hostIp,omitempty"`
+	HostPort      string `json:"hostPort,omitempty"`
+}
+
+// NetworkAttachment attaches the container to a user-defined network at
+// create time, optionally under extra DNS aliases.
+type NetworkAttachment struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// CreateContainerRequest is the JSON body accepted by POST /containers.
+type CreateContainerRequest struct {
+	Image  string            `json:"image"`
+	Cmd    []string          `json:"cmd,omitempty"`
+	Env    []string          `json:"env,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Mounts []MountRequest    `json:"mounts,omitempty"`
+
+	Resources ResourceLimits `json:"resources,omitempty"`
+
+	RestartPolicy     string            `json:"restartPolicy,omitempty"`
+	RestartMaxRetries int               `json:"restartMaxRetries,omitempty"`
+	CapAdd            []string          `json:"capAdd,omitempty"`
+	CapDrop           []string          `json:"capDrop,omitempty"`
+	SecurityOpt       []string          `json:"securityOpt,omitempty"`
+	ReadonlyRootfs    bool              `json:"readonlyRootfs,omitempty"`
+	Tmpfs             map[string]string `json:"tmpfs,omitempty"`
+
+	Ports           []PortBinding `json:"ports,omitempty"`
+	PublishAllPorts bool          `json:"publishAllPorts,omitempty"`
+
+	NetworkMode string              `json:"networkMode,omitempty"`
+	Networks    []NetworkAttachment `json:"networks,omitempty"`
+}
+
+// CreateContainerResponse is returned after a container is created.
+type CreateContainerResponse struct {
+	ID       string   `json:"id"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// StopContainerResponse reports whether a stop request succeeded.
+type StopContainerResponse struct {
+	OK bool `json:"ok"`
+}
+
+// ContainerSummary is the shape returned by GET /containers.
+type ContainerSummary struct {
+	ID      string   `json:"id"`
+	Names   []string `json:"names"`
+	Image   string   `json:"image"`
+	State   string   `json:"state"`
+	Status  string   `json:"status"`
+	Created int64    `json:"created"`
+}
+
+// ContainerInspect is the shape returned by GET /containers/{id}.
+type ContainerInspect struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Image   string    `json:"image"`
+	State   string    `json:"state"`
+	Created time.Time `json:"created"`
+}
+
+// WaitResponse is returned by POST /containers/{id}/wait once the
+// container exits.
+type WaitResponse struct {
+	StatusCode int64  `json:"statusCode"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ExecCreateRequest is the JSON body accepted by POST /containers/{id}/exec.
+type ExecCreateRequest struct {
+	Cmd          []string `json:"cmd"`
+	Env          []string `json:"env,omitempty"`
+	Tty          bool     `json:"tty,omitempty"`
+	AttachStdin  bool     `json:"attachStdin,omitempty"`
+	AttachStdout bool     `json:"attachStdout,omitempty"`
+	AttachStderr bool     `json:"attachStderr,omitempty"`
+}
+
+// ExecCreateResponse carries the ID of a newly created exec instance.
+type ExecCreateResponse struct {
+	ID string `json:"id"`
+}
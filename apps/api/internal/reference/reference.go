@@ -0,0 +1,62 @@
+// Package reference parses Docker image references the same way the
+// docker CLI does, splitting registry/repo:tag@digest so callers always
+// get a fully-qualified, default-tagged reference instead of juggling
+// raw strings.
+package reference
+
+import (
+	"fmt"
+
+	"github.com/distribution/reference"
+)
+
+// Ref is a fully-parsed image reference.
+type Ref struct {
+	// Registry is the hostname (and optional port) hosting the image,
+	// e.g. "docker.io" or "registry.example.com:5000".
+	Registry string
+	// Repository is the image path without registry, tag, or digest,
+	// e.g. "library/nginx".
+	Repository string
+	// Tag is empty when the reference is pinned to a digest.
+	Tag string
+	// Digest is empty when the reference is pinned to a tag.
+	Digest string
+}
+
+// String renders the reference back into a single pullable string,
+// preferring the digest when both are present so pulls stay
+// reproducible.
+func (r Ref) String() string {
+	s := r.Registry + "/" + r.Repository
+	if r.Digest != "" {
+		return fmt.Sprintf("%s@%s", s, r.Digest)
+	}
+	return fmt.Sprintf("%s:%s", s, r.Tag)
+}
+
+// Parse splits image into registry, repository, tag, and digest,
+// defaulting the tag to "latest" when neither a tag nor digest is
+// given, the same normalization docker's ParseRepositoryTag performs.
+func Parse(image string) (Ref, error) {
+	named, err := reference.ParseDockerRef(image)
+	if err != nil {
+		return Ref{}, fmt.Errorf("invalid image reference %q: %w", image, err)
+	}
+
+	ref := Ref{
+		Registry:   reference.Domain(named),
+		Repository: reference.Path(named),
+	}
+
+	if canonical, ok := named.(reference.Canonical); ok {
+		ref.Digest = canonical.Digest().String()
+		return ref, nil
+	}
+
+	named = reference.TagNameOnly(named)
+	if tagged, ok := named.(reference.NamedTagged); ok {
+		ref.Tag = tagged.Tag()
+	}
+	return ref, nil
+}
@@ -0,0 +1,70 @@
+package reference
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name  string
+		image string
+		want  Ref
+	}{
+		{
+			name:  "defaults to docker.io and latest",
+			image: "nginx",
+			want:  Ref{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"},
+		},
+		{
+			name:  "explicit tag",
+			image: "nginx:1.27",
+			want:  Ref{Registry: "docker.io", Repository: "library/nginx", Tag: "1.27"},
+		},
+		{
+			name:  "private registry with port",
+			image: "registry.example.com:5000/team/app:v2",
+			want:  Ref{Registry: "registry.example.com:5000", Repository: "team/app", Tag: "v2"},
+		},
+		{
+			name:  "digest pin takes precedence over tag",
+			image: "nginx:1.27@sha256:" + sha256Hex,
+			want:  Ref{Registry: "docker.io", Repository: "library/nginx", Digest: "sha256:" + sha256Hex},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse(tc.image)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tc.image, err)
+			}
+			if got != tc.want {
+				t.Fatalf("Parse(%q) = %+v, want %+v", tc.image, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalidReference(t *testing.T) {
+	if _, err := Parse("UPPERCASE_NOT_ALLOWED"); err == nil {
+		t.Fatal("Parse of an invalid reference returned no error")
+	}
+}
+
+func TestRefStringPrefersDigest(t *testing.T) {
+	r := Ref{Registry: "docker.io", Repository: "library/nginx", Tag: "latest", Digest: "sha256:" + sha256Hex}
+	want := "docker.io/library/nginx@sha256:" + sha256Hex
+	if got := r.String(); got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestRefStringFallsBackToTag(t *testing.T) {
+	r := Ref{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"}
+	want := "docker.io/library/nginx:latest"
+	if got := r.String(); got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+}
+
+// sha256Hex is an arbitrary, validly-shaped sha256 hex digest used to
+// build @sha256:... test references.
+const sha256Hex = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
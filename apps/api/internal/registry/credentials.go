@@ -0,0 +1,123 @@
+// Package registry stores credentials for one or more container
+// registries, modeled on docker's cliconfig/credentials package, so the
+// API server can pull from private registries on a caller's behalf.
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	registrytypes "github.com/docker/docker/api/types/registry"
+)
+
+// Store persists registry credentials keyed by server address (e.g.
+// "docker.io" or "registry.example.com:5000").
+type Store interface {
+	Get(serverAddress string) (registrytypes.AuthConfig, error)
+	Store(auth registrytypes.AuthConfig) error
+	Erase(serverAddress string) error
+}
+
+// memoryStore keeps credentials in process memory only.
+type memoryStore struct {
+	mu    sync.RWMutex
+	creds map[string]registrytypes.AuthConfig
+}
+
+// NewInMemoryStore returns a Store that never touches disk.
+func NewInMemoryStore() Store {
+	return &memoryStore{creds: make(map[string]registrytypes.AuthConfig)}
+}
+
+func (s *memoryStore) Get(serverAddress string) (registrytypes.AuthConfig, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.creds[serverAddress], nil
+}
+
+func (s *memoryStore) Store(auth registrytypes.AuthConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.creds[auth.ServerAddress] = auth
+	return nil
+}
+
+func (s *memoryStore) Erase(serverAddress string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.creds, serverAddress)
+	return nil
+}
+
+// fileStore persists credentials as JSON on disk so they survive
+// process restarts.
+type fileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore returns a Store backed by the JSON file at path,
+// creating it on first write if it doesn't yet exist.
+func NewFileStore(path string) Store {
+	return &fileStore{path: path}
+}
+
+func (s *fileStore) Get(serverAddress string) (registrytypes.AuthConfig, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	creds, err := s.load()
+	if err != nil {
+		return registrytypes.AuthConfig{}, err
+	}
+	return creds[serverAddress], nil
+}
+
+func (s *fileStore) Store(auth registrytypes.AuthConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	creds, err := s.load()
+	if err != nil {
+		return err
+	}
+	creds[auth.ServerAddress] = auth
+	return s.save(creds)
+}
+
+func (s *fileStore) Erase(serverAddress string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	creds, err := s.load()
+	if err != nil {
+		return err
+	}
+	delete(creds, serverAddress)
+	return s.save(creds)
+}
+
+func (s *fileStore) load() (map[string]registrytypes.AuthConfig, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return make(map[string]registrytypes.AuthConfig), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credential store: %w", err)
+	}
+	creds := make(map[string]registrytypes.AuthConfig)
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse credential store: %w", err)
+	}
+	return creds, nil
+}
+
+func (s *fileStore) save(creds map[string]registrytypes.AuthConfig) error {
+	data, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode credential store: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write credential store: %w", err)
+	}
+	return nil
+}
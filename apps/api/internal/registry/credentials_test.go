@@ -0,0 +1,72 @@
+package registry
+
+import (
+	"path/filepath"
+	"testing"
+
+	registrytypes "github.com/docker/docker/api/types/registry"
+)
+
+func testStoreRoundTrip(t *testing.T, store Store) {
+	t.Helper()
+
+	auth, err := store.Get("docker.io")
+	if err != nil {
+		t.Fatalf("Get on empty store: %v", err)
+	}
+	if auth != (registrytypes.AuthConfig{}) {
+		t.Fatalf("Get on empty store = %+v, want zero value", auth)
+	}
+
+	want := registrytypes.AuthConfig{ServerAddress: "docker.io", Username: "alice", Password: "hunter2"}
+	if err := store.Store(want); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	got, err := store.Get("docker.io")
+	if err != nil {
+		t.Fatalf("Get after Store: %v", err)
+	}
+	if got != want {
+		t.Fatalf("Get after Store = %+v, want %+v", got, want)
+	}
+
+	if err := store.Erase("docker.io"); err != nil {
+		t.Fatalf("Erase: %v", err)
+	}
+	got, err = store.Get("docker.io")
+	if err != nil {
+		t.Fatalf("Get after Erase: %v", err)
+	}
+	if got != (registrytypes.AuthConfig{}) {
+		t.Fatalf("Get after Erase = %+v, want zero value", got)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStoreRoundTrip(t, NewInMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "creds.json")
+	testStoreRoundTrip(t, NewFileStore(path))
+}
+
+func TestFileStorePersistsAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "creds.json")
+
+	first := NewFileStore(path)
+	want := registrytypes.AuthConfig{ServerAddress: "registry.example.com", Username: "bob"}
+	if err := first.Store(want); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	second := NewFileStore(path)
+	got, err := second.Get("registry.example.com")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got != want {
+		t.Fatalf("Get from fresh store = %+v, want %+v", got, want)
+	}
+}